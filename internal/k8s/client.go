@@ -3,13 +3,35 @@ package k8s
 //go:generate popeye gen
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/derailed/popeye/pkg/config"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	batchlistersv1beta1 "k8s.io/client-go/listers/batch/v1beta1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/cache"
 	metricsapi "k8s.io/metrics/pkg/apis/metrics"
 	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
@@ -17,45 +39,89 @@ import (
 var (
 	supportedMetricsAPIVersions = []string{"v1beta1"}
 	systemNS                    = []string{"kube-system", "kube-public"}
+
+	// resyncPeriod is how often the shared informers resync their store
+	// against the apiserver.
+	resyncPeriod = 10 * time.Minute
 )
 
 // Client represents a Kubernetes api server client.
 type Client struct {
 	*config.Config
 
-	api kubernetes.Interface
-
-	allPods map[string]v1.Pod
-	allNSs  map[string]v1.Namespace
-	eps     map[string]v1.Endpoints
-	allCRBs map[string]rbacv1.ClusterRoleBinding
-	allRBs  map[string]rbacv1.RoleBinding
-	allCMs  map[string]v1.ConfigMap
-	allSecs map[string]v1.Secret
-	allSAs  map[string]v1.ServiceAccount
+	api        kubernetes.Interface
+	factory    informers.SharedInformerFactory
+	synced     bool
+	stopCh     chan struct{}
+	dynClient  dynamic.Interface
+	restMapper meta.RESTMapper
+	watchFn    WatchFunc
+
+	podLister  corelisters.PodLister
+	nsLister   corelisters.NamespaceLister
+	epLister   corelisters.EndpointsLister
+	cmLister   corelisters.ConfigMapLister
+	secLister  corelisters.SecretLister
+	saLister   corelisters.ServiceAccountLister
+	svcLister  corelisters.ServiceLister
+	nodeLister corelisters.NodeLister
+	rbLister   rbaclisters.RoleBindingLister
+	crbLister  rbaclisters.ClusterRoleBindingLister
+
+	dpLister  appslisters.DeploymentLister
+	stsLister appslisters.StatefulSetLister
+	dsLister  appslisters.DaemonSetLister
+	rsLister  appslisters.ReplicaSetLister
+	jobLister batchlisters.JobLister
+	cjLister  batchlistersv1beta1.CronJobLister
 }
 
 // NewClient returns a dialable api server configuration.
 func NewClient(config *config.Config) *Client {
-	return &Client{Config: config}
+	return &Client{Config: config, stopCh: make(chan struct{})}
+}
+
+// WatchFunc is invoked whenever a watched resource's cache changes.
+type WatchFunc func()
+
+// OnChange registers fn to be called on every cache add/update/delete once
+// --watch is enabled (see config.Watch), so a caller can re-run sanitizers
+// against the fresh cache instead of polling the apiserver on a timer.
+// It must be called before Dial starts the informers.
+func (c *Client) OnChange(fn WatchFunc) {
+	c.watchFn = fn
+}
+
+func (c *Client) notifyWatch() {
+	if c.watchFn != nil {
+		c.watchFn()
+	}
 }
 
 // DialOrDie returns an api server client connection or dies.
-func (c *Client) DialOrDie() kubernetes.Interface {
-	client, err := c.Dial()
+//
+// Deprecated: panicking on a transient apiserver hiccup takes the whole
+// binary down with it. Call Dial and propagate the error instead.
+func (c *Client) DialOrDie(ctx context.Context) kubernetes.Interface {
+	client, err := c.Dial(ctx)
 	if err != nil {
 		panic(err)
 	}
 	return client
 }
 
-// Dial returns a handle to api server.
-func (c *Client) Dial() (kubernetes.Interface, error) {
+// Dial returns a handle to api server. It prefers the in-cluster service
+// account config when running as a Pod, falling back to kubeconfig so the
+// same binary works from a CronJob/operator or a developer's workstation.
+func (c *Client) Dial(ctx context.Context) (kubernetes.Interface, error) {
 	if c.api != nil {
 		return c.api, nil
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-	cfg, err := c.Config.RESTConfig()
+	cfg, err := c.restConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -63,12 +129,143 @@ func (c *Client) Dial() (kubernetes.Interface, error) {
 	if c.api, err = kubernetes.NewForConfig(cfg); err != nil {
 		return nil, err
 	}
-	return c.api, nil
+
+	return c.api, c.startInformers(ctx)
+}
+
+// restConfig resolves the rest.Config to dial the apiserver with, trying
+// the in-cluster service account first and falling back to the configured
+// kubeconfig. Impersonation settings, if any, are applied on top.
+func (c *Client) restConfig() (*rest.Config, error) {
+	var (
+		cfg *rest.Config
+		err error
+	)
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		if cfg, err = rest.InClusterConfig(); err != nil {
+			return nil, err
+		}
+	} else if cfg, err = c.Config.RESTConfig(); err != nil {
+		return nil, err
+	}
+
+	if user, groups := c.Config.ImpersonateUser(), c.Config.ImpersonateGroups(); user != "" {
+		cfg.Impersonate = rest.ImpersonationConfig{UserName: user, Groups: groups}
+	}
+
+	return cfg, nil
+}
+
+// Healthz probes the apiserver's readiness endpoint so a scan can bail out
+// early with a clear error instead of failing sanitizer-by-sanitizer.
+func (c *Client) Healthz(ctx context.Context) error {
+	if _, err := c.Dial(ctx); err != nil {
+		return err
+	}
+
+	body, err := c.api.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("apiserver not ready: %w", err)
+	}
+	if string(body) != "ok" {
+		return fmt.Errorf("apiserver not ready: %s", string(body))
+	}
+
+	return nil
+}
+
+// startInformers builds a SharedInformerFactory over the resources Popeye
+// sanitizes, starts it and blocks until the local caches are primed. It is
+// a no-op if the factory is already running, so repeated calls to Dial are
+// cheap.
+func (c *Client) startInformers(ctx context.Context) error {
+	if c.factory != nil {
+		return nil
+	}
+
+	c.factory = informers.NewSharedInformerFactoryWithOptions(c.api, resyncPeriod,
+		informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+			o.Limit = c.Config.PageSize
+		}),
+	)
+
+	pods := c.factory.Core().V1().Pods()
+	nss := c.factory.Core().V1().Namespaces()
+	eps := c.factory.Core().V1().Endpoints()
+	cms := c.factory.Core().V1().ConfigMaps()
+	secs := c.factory.Core().V1().Secrets()
+	sas := c.factory.Core().V1().ServiceAccounts()
+	svcs := c.factory.Core().V1().Services()
+	nodes := c.factory.Core().V1().Nodes()
+	rbs := c.factory.Rbac().V1().RoleBindings()
+	crbs := c.factory.Rbac().V1().ClusterRoleBindings()
+	dps := c.factory.Apps().V1().Deployments()
+	stss := c.factory.Apps().V1().StatefulSets()
+	dss := c.factory.Apps().V1().DaemonSets()
+	rss := c.factory.Apps().V1().ReplicaSets()
+	jobs := c.factory.Batch().V1().Jobs()
+	cjs := c.factory.Batch().V1beta1().CronJobs()
+
+	c.podLister, c.nsLister, c.epLister = pods.Lister(), nss.Lister(), eps.Lister()
+	c.cmLister, c.secLister, c.saLister = cms.Lister(), secs.Lister(), sas.Lister()
+	c.svcLister, c.nodeLister = svcs.Lister(), nodes.Lister()
+	c.rbLister, c.crbLister = rbs.Lister(), crbs.Lister()
+	c.dpLister, c.stsLister, c.dsLister, c.rsLister = dps.Lister(), stss.Lister(), dss.Lister(), rss.Lister()
+	c.jobLister, c.cjLister = jobs.Lister(), cjs.Lister()
+
+	if c.Config.Watch() {
+		handler := cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { c.notifyWatch() },
+			UpdateFunc: func(interface{}, interface{}) { c.notifyWatch() },
+			DeleteFunc: func(interface{}) { c.notifyWatch() },
+		}
+		for _, informer := range []cache.SharedIndexInformer{
+			pods.Informer(), nss.Informer(), eps.Informer(), cms.Informer(), secs.Informer(),
+			sas.Informer(), svcs.Informer(), nodes.Informer(), rbs.Informer(), crbs.Informer(),
+			dps.Informer(), stss.Informer(), dss.Informer(), rss.Informer(), jobs.Informer(), cjs.Informer(),
+		} {
+			informer.AddEventHandler(handler)
+		}
+	}
+
+	c.factory.Start(c.stopCh)
+
+	// WaitForCacheSync blocks on c.stopCh alone, so it would ignore a
+	// caller's ctx deadline on a slow/huge cluster. Derive a wait-scoped
+	// stop channel that also closes when ctx is done, without tearing
+	// down the (long-lived) factory itself. done lets the goroutine exit
+	// once the wait is over instead of leaking until ctx is eventually
+	// cancelled (often never, for a context.Background() caller).
+	waitCh := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(waitCh)
+		case <-c.stopCh:
+			close(waitCh)
+		case <-done:
+		}
+	}()
+
+	synced := c.factory.WaitForCacheSync(waitCh)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for informerType, ok := range synced {
+		if !ok {
+			return fmt.Errorf("failed to sync informer cache for %v", informerType)
+		}
+	}
+	c.synced = true
+
+	return nil
 }
 
 // ClusterHasMetrics checks if metrics server is available on the cluster.
-func (c *Client) ClusterHasMetrics() bool {
-	srv, err := c.Dial()
+func (c *Client) ClusterHasMetrics(ctx context.Context) bool {
+	srv, err := c.Dial(ctx)
 	if err != nil {
 		return false
 	}
@@ -93,18 +290,18 @@ func (c *Client) ClusterHasMetrics() bool {
 }
 
 // FetchNodesMetrics fetch all node metrics.
-func (c *Client) FetchNodesMetrics() ([]mv1beta1.NodeMetrics, error) {
-	return FetchNodesMetrics(c)
+func (c *Client) FetchNodesMetrics(ctx context.Context) ([]mv1beta1.NodeMetrics, error) {
+	return FetchNodesMetrics(ctx, c)
 }
 
 // FetchPodsMetrics fetch all pods metrics in a given namespace.
-func (c *Client) FetchPodsMetrics(ns string) ([]mv1beta1.PodMetrics, error) {
-	return FetchPodsMetrics(c, ns)
+func (c *Client) FetchPodsMetrics(ctx context.Context, ns string) ([]mv1beta1.PodMetrics, error) {
+	return FetchPodsMetrics(ctx, c, ns)
 }
 
 // InUseNamespaces returns a list of namespaces referenced by pods.
-func (c *Client) InUseNamespaces(nss []string) {
-	pods, err := c.ListPods()
+func (c *Client) InUseNamespaces(ctx context.Context, nss []string) {
+	pods, err := c.ListPods(ctx)
 	if err != nil {
 		return
 	}
@@ -122,27 +319,27 @@ func (c *Client) InUseNamespaces(nss []string) {
 }
 
 // ListAllRBs returns all RoleBindings.
-func (c *Client) ListAllRBs() (map[string]rbacv1.RoleBinding, error) {
-	if c.allRBs != nil {
-		return c.allRBs, nil
+func (c *Client) ListAllRBs(ctx context.Context) (map[string]rbacv1.RoleBinding, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
 	}
 
-	ll, err := c.DialOrDie().RbacV1().RoleBindings("").List(metav1.ListOptions{})
+	ll, err := c.rbLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.allRBs = make(map[string]rbacv1.RoleBinding, len(ll.Items))
-	for _, rb := range ll.Items {
-		c.allRBs[fqn(rb.Namespace, rb.Name)] = rb
+	res := make(map[string]rbacv1.RoleBinding, len(ll))
+	for _, rb := range ll {
+		res[fqn(rb.Namespace, rb.Name)] = *rb
 	}
 
-	return c.allRBs, nil
+	return res, nil
 }
 
 // ListRBs lists all available RBs in a given namespace.
-func (c *Client) ListRBs() (map[string]rbacv1.RoleBinding, error) {
-	rbs, err := c.ListAllRBs()
+func (c *Client) ListRBs(ctx context.Context) (map[string]rbacv1.RoleBinding, error) {
+	rbs, err := c.ListAllRBs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -158,48 +355,48 @@ func (c *Client) ListRBs() (map[string]rbacv1.RoleBinding, error) {
 }
 
 // ListAllCRBs returns a ClusterRoleBindings.
-func (c *Client) ListAllCRBs() (map[string]rbacv1.ClusterRoleBinding, error) {
-	if c.allCRBs != nil {
-		return c.allCRBs, nil
+func (c *Client) ListAllCRBs(ctx context.Context) (map[string]rbacv1.ClusterRoleBinding, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
 	}
 
-	ll, err := c.DialOrDie().RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+	ll, err := c.crbLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.allCRBs = make(map[string]rbacv1.ClusterRoleBinding, len(ll.Items))
-	for _, crb := range ll.Items {
-		c.allCRBs[crb.Name] = crb
+	res := make(map[string]rbacv1.ClusterRoleBinding, len(ll))
+	for _, crb := range ll {
+		res[crb.Name] = *crb
 	}
 
-	return c.allCRBs, nil
+	return res, nil
 }
 
 // ListEndpoints returns a endpoint by name.
-func (c *Client) ListEndpoints() (map[string]v1.Endpoints, error) {
-	if c.eps != nil {
-		return c.eps, nil
+func (c *Client) ListEndpoints(ctx context.Context) (map[string]v1.Endpoints, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
 	}
 
-	ll, err := c.DialOrDie().CoreV1().Endpoints(c.Config.ActiveNamespace()).List(metav1.ListOptions{})
+	ll, err := c.epLister.Endpoints(c.Config.ActiveNamespace()).List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.eps = make(map[string]v1.Endpoints, len(ll.Items))
-	for _, ep := range ll.Items {
+	res := make(map[string]v1.Endpoints, len(ll))
+	for _, ep := range ll {
 		if !c.Config.ExcludedNS(ep.Namespace) {
-			c.eps[fqn(ep.Namespace, ep.Name)] = ep
+			res[fqn(ep.Namespace, ep.Name)] = *ep
 		}
 	}
 
-	return c.eps, nil
+	return res, nil
 }
 
 // GetEndpoints returns a endpoint by name.
-func (c *Client) GetEndpoints(svcFQN string) (*v1.Endpoints, error) {
-	eps, err := c.ListEndpoints()
+func (c *Client) GetEndpoints(ctx context.Context, svcFQN string) (*v1.Endpoints, error) {
+	eps, err := c.ListEndpoints(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -212,16 +409,20 @@ func (c *Client) GetEndpoints(svcFQN string) (*v1.Endpoints, error) {
 }
 
 // ListServices lists all available services in a given namespace.
-func (c *Client) ListServices() ([]v1.Service, error) {
-	ll, err := c.DialOrDie().CoreV1().Services(c.Config.ActiveNamespace()).List(metav1.ListOptions{})
+func (c *Client) ListServices(ctx context.Context) ([]v1.Service, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.svcLister.Services(c.Config.ActiveNamespace()).List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	svcs := make([]v1.Service, 0, len(ll.Items))
-	for _, svc := range ll.Items {
+	svcs := make([]v1.Service, 0, len(ll))
+	for _, svc := range ll {
 		if c.matchActiveNS(svc.Namespace) && !c.Config.ExcludedNS(svc.Namespace) {
-			svcs = append(svcs, svc)
+			svcs = append(svcs, *svc)
 		}
 	}
 
@@ -229,16 +430,20 @@ func (c *Client) ListServices() ([]v1.Service, error) {
 }
 
 // ListNodes list all available nodes on the cluster.
-func (c *Client) ListNodes() ([]v1.Node, error) {
-	ll, err := c.DialOrDie().CoreV1().Nodes().List(metav1.ListOptions{})
+func (c *Client) ListNodes(ctx context.Context) ([]v1.Node, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.nodeLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	nodes := make([]v1.Node, 0, len(ll.Items))
-	for _, no := range ll.Items {
+	nodes := make([]v1.Node, 0, len(ll))
+	for _, no := range ll {
 		if !c.Config.ExcludedNode(no.Name) {
-			nodes = append(nodes, no)
+			nodes = append(nodes, *no)
 		}
 	}
 
@@ -246,8 +451,8 @@ func (c *Client) ListNodes() ([]v1.Node, error) {
 }
 
 // GetPod returns a pod via a label query.
-func (c *Client) GetPod(sel map[string]string) (*v1.Pod, error) {
-	pods, err := c.ListPods()
+func (c *Client) GetPod(ctx context.Context, sel map[string]string) (*v1.Pod, error) {
+	pods, err := c.ListPods(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -268,8 +473,8 @@ func (c *Client) GetPod(sel map[string]string) (*v1.Pod, error) {
 }
 
 // ListPods list all available pods.
-func (c *Client) ListPods() (map[string]v1.Pod, error) {
-	pods, err := c.ListAllPods()
+func (c *Client) ListPods(ctx context.Context) (map[string]v1.Pod, error) {
+	pods, err := c.ListAllPods(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -285,27 +490,90 @@ func (c *Client) ListPods() (map[string]v1.Pod, error) {
 }
 
 // ListAllPods fetch all pods on the cluster.
-func (c *Client) ListAllPods() (map[string]v1.Pod, error) {
-	if len(c.allPods) != 0 {
-		return c.allPods, nil
+func (c *Client) ListAllPods(ctx context.Context) (map[string]v1.Pod, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
 	}
 
-	ll, err := c.DialOrDie().CoreV1().Pods("").List(metav1.ListOptions{})
+	ll, err := c.podLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.allPods = make(map[string]v1.Pod, len(ll.Items))
-	for _, po := range ll.Items {
-		c.allPods[fqn(po.Namespace, po.Name)] = po
+	res := make(map[string]v1.Pod, len(ll))
+	for _, po := range ll {
+		res[fqn(po.Namespace, po.Name)] = *po
 	}
 
-	return c.allPods, nil
+	return res, nil
+}
+
+// ListOptions scopes a List call to a subset of the cluster, so sanitizers
+// can avoid pulling every object of a kind when they only need a slice of
+// it (e.g. pods on a given node for node-pressure checks).
+type ListOptions struct {
+	LabelSelector string
+	FieldSelector string
+}
+
+// ListPodsWithOptions lists pods honoring a label and/or field selector
+// (e.g. FieldSelector: "spec.nodeName=<node>" to scope a node-pressure
+// check to that node's pods) instead of pulling every pod in the cluster.
+func (c *Client) ListPodsWithOptions(ctx context.Context, opts ListOptions) (map[string]v1.Pod, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	sel := labels.Everything()
+	if opts.LabelSelector != "" {
+		parsed, err := labels.Parse(opts.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		sel = parsed
+	}
+
+	var fsel fields.Selector
+	if opts.FieldSelector != "" {
+		parsed, err := fields.ParseSelector(opts.FieldSelector)
+		if err != nil {
+			return nil, err
+		}
+		fsel = parsed
+	}
+
+	ll, err := c.podLister.List(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]v1.Pod, len(ll))
+	for _, po := range ll {
+		if fsel != nil && !fsel.Matches(podFieldSet(po)) {
+			continue
+		}
+		if c.matchActiveNS(po.Namespace) && !c.Config.ExcludedNS(po.Namespace) {
+			res[fqn(po.Namespace, po.Name)] = *po
+		}
+	}
+
+	return res, nil
+}
+
+// podFieldSet exposes the pod fields selectable via FieldSelector, mirroring
+// the set the apiserver itself supports for pods.
+func podFieldSet(po *v1.Pod) fields.Set {
+	return fields.Set{
+		"metadata.name":      po.Name,
+		"metadata.namespace": po.Namespace,
+		"spec.nodeName":      po.Spec.NodeName,
+		"status.phase":       string(po.Status.Phase),
+	}
 }
 
 // ListCMs list all included ConfigMaps.
-func (c *Client) ListCMs() (map[string]v1.ConfigMap, error) {
-	cms, err := c.ListAllCMs()
+func (c *Client) ListCMs(ctx context.Context) (map[string]v1.ConfigMap, error) {
+	cms, err := c.ListAllCMs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -321,27 +589,27 @@ func (c *Client) ListCMs() (map[string]v1.ConfigMap, error) {
 }
 
 // ListAllCMs fetch all configmaps on the cluster.
-func (c *Client) ListAllCMs() (map[string]v1.ConfigMap, error) {
-	if len(c.allCMs) != 0 {
-		return c.allCMs, nil
+func (c *Client) ListAllCMs(ctx context.Context) (map[string]v1.ConfigMap, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
 	}
 
-	ll, err := c.DialOrDie().CoreV1().ConfigMaps("").List(metav1.ListOptions{})
+	ll, err := c.cmLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.allCMs = make(map[string]v1.ConfigMap, len(ll.Items))
-	for _, cm := range ll.Items {
-		c.allCMs[fqn(cm.Namespace, cm.Name)] = cm
+	res := make(map[string]v1.ConfigMap, len(ll))
+	for _, cm := range ll {
+		res[fqn(cm.Namespace, cm.Name)] = *cm
 	}
 
-	return c.allCMs, nil
+	return res, nil
 }
 
 // ListSecs list all included Secrets.
-func (c *Client) ListSecs() (map[string]v1.Secret, error) {
-	secs, err := c.ListAllSecs()
+func (c *Client) ListSecs(ctx context.Context) (map[string]v1.Secret, error) {
+	secs, err := c.ListAllSecs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -357,27 +625,27 @@ func (c *Client) ListSecs() (map[string]v1.Secret, error) {
 }
 
 // ListAllSecs fetch all secrets on the cluster.
-func (c *Client) ListAllSecs() (map[string]v1.Secret, error) {
-	if len(c.allSecs) != 0 {
-		return c.allSecs, nil
+func (c *Client) ListAllSecs(ctx context.Context) (map[string]v1.Secret, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
 	}
 
-	ll, err := c.DialOrDie().CoreV1().Secrets("").List(metav1.ListOptions{})
+	ll, err := c.secLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.allSecs = make(map[string]v1.Secret, len(ll.Items))
-	for _, sec := range ll.Items {
-		c.allSecs[fqn(sec.Namespace, sec.Name)] = sec
+	res := make(map[string]v1.Secret, len(ll))
+	for _, sec := range ll {
+		res[fqn(sec.Namespace, sec.Name)] = *sec
 	}
 
-	return c.allSecs, nil
+	return res, nil
 }
 
 // ListSAs list all included ConfigMaps.
-func (c *Client) ListSAs() (map[string]v1.ServiceAccount, error) {
-	sas, err := c.ListAllSAs()
+func (c *Client) ListSAs(ctx context.Context) (map[string]v1.ServiceAccount, error) {
+	sas, err := c.ListAllSAs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -393,27 +661,27 @@ func (c *Client) ListSAs() (map[string]v1.ServiceAccount, error) {
 }
 
 // ListAllSAs fetch all ServiceAccount on the cluster.
-func (c *Client) ListAllSAs() (map[string]v1.ServiceAccount, error) {
-	if len(c.allSAs) != 0 {
-		return c.allSAs, nil
+func (c *Client) ListAllSAs(ctx context.Context) (map[string]v1.ServiceAccount, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
 	}
 
-	ll, err := c.DialOrDie().CoreV1().ServiceAccounts("").List(metav1.ListOptions{})
+	ll, err := c.saLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.allSAs = make(map[string]v1.ServiceAccount, len(ll.Items))
-	for _, sa := range ll.Items {
-		c.allSAs[fqn(sa.Namespace, sa.Name)] = sa
+	res := make(map[string]v1.ServiceAccount, len(ll))
+	for _, sa := range ll {
+		res[fqn(sa.Namespace, sa.Name)] = *sa
 	}
 
-	return c.allSAs, nil
+	return res, nil
 }
 
 // ListNS lists all available namespaces.
-func (c *Client) ListNS() (map[string]v1.Namespace, error) {
-	nss, err := c.ListAllNS()
+func (c *Client) ListNS(ctx context.Context) (map[string]v1.Namespace, error) {
+	nss, err := c.ListAllNS(ctx)
 	if err != nil {
 		return nil, nil
 	}
@@ -429,22 +697,268 @@ func (c *Client) ListNS() (map[string]v1.Namespace, error) {
 }
 
 // ListAllNS fetch all namespaces on this cluster.
-func (c *Client) ListAllNS() (map[string]v1.Namespace, error) {
-	if len(c.allNSs) != 0 {
-		return c.allNSs, nil
+func (c *Client) ListAllNS(ctx context.Context) (map[string]v1.Namespace, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	nn, err := c.nsLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]v1.Namespace, len(nn))
+	for _, ns := range nn {
+		res[ns.Name] = *ns
+	}
+
+	return res, nil
+}
+
+// ListDPs lists all included Deployments.
+func (c *Client) ListDPs(ctx context.Context) (map[string]appsv1.Deployment, error) {
+	dps, err := c.ListAllDPs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]appsv1.Deployment, len(dps))
+	for fqn, dp := range dps {
+		if c.matchActiveNS(dp.Namespace) && !c.Config.ExcludedNS(dp.Namespace) {
+			res[fqn] = dp
+		}
 	}
 
-	nn, err := c.DialOrDie().CoreV1().Namespaces().List(metav1.ListOptions{})
+	return res, nil
+}
+
+// ListAllDPs fetch all deployments on the cluster.
+func (c *Client) ListAllDPs(ctx context.Context) (map[string]appsv1.Deployment, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.dpLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	c.allNSs = make(map[string]v1.Namespace, len(nn.Items))
-	for _, ns := range nn.Items {
-		c.allNSs[ns.Name] = ns
+	res := make(map[string]appsv1.Deployment, len(ll))
+	for _, dp := range ll {
+		res[fqn(dp.Namespace, dp.Name)] = *dp
 	}
 
-	return c.allNSs, nil
+	return res, nil
+}
+
+// ListSTSs lists all included StatefulSets.
+func (c *Client) ListSTSs(ctx context.Context) (map[string]appsv1.StatefulSet, error) {
+	stss, err := c.ListAllSTSs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]appsv1.StatefulSet, len(stss))
+	for fqn, sts := range stss {
+		if c.matchActiveNS(sts.Namespace) && !c.Config.ExcludedNS(sts.Namespace) {
+			res[fqn] = sts
+		}
+	}
+
+	return res, nil
+}
+
+// ListAllSTSs fetch all statefulsets on the cluster.
+func (c *Client) ListAllSTSs(ctx context.Context) (map[string]appsv1.StatefulSet, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.stsLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]appsv1.StatefulSet, len(ll))
+	for _, sts := range ll {
+		res[fqn(sts.Namespace, sts.Name)] = *sts
+	}
+
+	return res, nil
+}
+
+// ListDSs lists all included DaemonSets.
+func (c *Client) ListDSs(ctx context.Context) (map[string]appsv1.DaemonSet, error) {
+	dss, err := c.ListAllDSs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]appsv1.DaemonSet, len(dss))
+	for fqn, ds := range dss {
+		if c.matchActiveNS(ds.Namespace) && !c.Config.ExcludedNS(ds.Namespace) {
+			res[fqn] = ds
+		}
+	}
+
+	return res, nil
+}
+
+// ListAllDSs fetch all daemonsets on the cluster.
+func (c *Client) ListAllDSs(ctx context.Context) (map[string]appsv1.DaemonSet, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.dsLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]appsv1.DaemonSet, len(ll))
+	for _, ds := range ll {
+		res[fqn(ds.Namespace, ds.Name)] = *ds
+	}
+
+	return res, nil
+}
+
+// ListRSs lists all included ReplicaSets.
+func (c *Client) ListRSs(ctx context.Context) (map[string]appsv1.ReplicaSet, error) {
+	rss, err := c.ListAllRSs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]appsv1.ReplicaSet, len(rss))
+	for fqn, rs := range rss {
+		if c.matchActiveNS(rs.Namespace) && !c.Config.ExcludedNS(rs.Namespace) {
+			res[fqn] = rs
+		}
+	}
+
+	return res, nil
+}
+
+// ListAllRSs fetch all replicasets on the cluster.
+func (c *Client) ListAllRSs(ctx context.Context) (map[string]appsv1.ReplicaSet, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.rsLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]appsv1.ReplicaSet, len(ll))
+	for _, rs := range ll {
+		res[fqn(rs.Namespace, rs.Name)] = *rs
+	}
+
+	return res, nil
+}
+
+// ListJobs lists all included Jobs.
+func (c *Client) ListJobs(ctx context.Context) (map[string]batchv1.Job, error) {
+	jobs, err := c.ListAllJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]batchv1.Job, len(jobs))
+	for fqn, job := range jobs {
+		if c.matchActiveNS(job.Namespace) && !c.Config.ExcludedNS(job.Namespace) {
+			res[fqn] = job
+		}
+	}
+
+	return res, nil
+}
+
+// ListAllJobs fetch all jobs on the cluster.
+func (c *Client) ListAllJobs(ctx context.Context) (map[string]batchv1.Job, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.jobLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]batchv1.Job, len(ll))
+	for _, job := range ll {
+		res[fqn(job.Namespace, job.Name)] = *job
+	}
+
+	return res, nil
+}
+
+// ListCJs lists all included CronJobs.
+func (c *Client) ListCJs(ctx context.Context) (map[string]batchv1beta1.CronJob, error) {
+	cjs, err := c.ListAllCJs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]batchv1beta1.CronJob, len(cjs))
+	for fqn, cj := range cjs {
+		if c.matchActiveNS(cj.Namespace) && !c.Config.ExcludedNS(cj.Namespace) {
+			res[fqn] = cj
+		}
+	}
+
+	return res, nil
+}
+
+// ListAllCJs fetch all cronjobs on the cluster.
+func (c *Client) ListAllCJs(ctx context.Context) (map[string]batchv1beta1.CronJob, error) {
+	if _, err := c.Dial(ctx); err != nil {
+		return nil, err
+	}
+
+	ll, err := c.cjLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]batchv1beta1.CronJob, len(ll))
+	for _, cj := range ll {
+		res[fqn(cj.Namespace, cj.Name)] = *cj
+	}
+
+	return res, nil
+}
+
+// OwnerChain walks a pod's OwnerReferences back to the root controller,
+// e.g. Pod -> ReplicaSet -> Deployment, so sanitizers can reason about
+// workload controllers without reverse-engineering ownership from labels.
+func (c *Client) OwnerChain(ctx context.Context, pod v1.Pod) []metav1.OwnerReference {
+	var chain []metav1.OwnerReference
+
+	ns := pod.Namespace
+	ref := metav1.GetControllerOfNoCopy(&pod)
+	for ref != nil {
+		chain = append(chain, *ref)
+
+		switch ref.Kind {
+		case "ReplicaSet":
+			rss, err := c.ListAllRSs(ctx)
+			if err != nil {
+				return chain
+			}
+			rs, ok := rss[fqn(ns, ref.Name)]
+			if !ok {
+				return chain
+			}
+			ref = metav1.GetControllerOfNoCopy(&rs)
+		default:
+			return chain
+		}
+	}
+
+	return chain
 }
 
 func (c *Client) matchActiveNS(ns string) bool {
@@ -454,6 +968,87 @@ func (c *Client) matchActiveNS(ns string) bool {
 	return ns == c.Config.ActiveNamespace()
 }
 
+// RESTMapper returns a RESTMapper built from the discovered API group
+// resources, so callers can resolve a Kind to a GroupVersionResource.
+func (c *Client) RESTMapper(ctx context.Context) (meta.RESTMapper, error) {
+	if c.restMapper != nil {
+		return c.restMapper, nil
+	}
+
+	if err := c.dialDynamic(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.restMapper, nil
+}
+
+// ListCR lists every custom resource the cluster advertises for a given
+// GroupVersionResource, honoring the same namespace filtering rules as the
+// typed ListAll*/List* methods. Unlike the typed listers above, dynamic
+// resources aren't informer-backed, so results are paged in PageSize
+// chunks via Limit/Continue to avoid pulling an entire CRD listing into
+// memory in one apiserver round trip.
+func (c *Client) ListCR(ctx context.Context, gvr schema.GroupVersionResource, opts ListOptions) (map[string]unstructured.Unstructured, error) {
+	if err := c.dialDynamic(ctx); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]unstructured.Unstructured)
+	lo := metav1.ListOptions{
+		Limit:         c.Config.PageSize,
+		LabelSelector: opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
+	}
+	for {
+		ll, err := c.dynClient.Resource(gvr).List(ctx, lo)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range ll.Items {
+			if c.matchActiveNS(o.GetNamespace()) && !c.Config.ExcludedNS(o.GetNamespace()) {
+				res[fqn(o.GetNamespace(), o.GetName())] = o
+			}
+		}
+
+		if ll.GetContinue() == "" {
+			break
+		}
+		lo.Continue = ll.GetContinue()
+	}
+
+	return res, nil
+}
+
+// dialDynamic lazily builds the discovery-driven dynamic client and REST
+// mapper used to sanitize CRDs the sanitizers don't know about statically.
+func (c *Client) dialDynamic(ctx context.Context) error {
+	if c.dynClient != nil && c.restMapper != nil {
+		return nil
+	}
+
+	cfg, err := c.restConfig()
+	if err != nil {
+		return err
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	grs, err := restmapper.GetAPIGroupResources(dc)
+	if err != nil {
+		return err
+	}
+	c.restMapper = restmapper.NewDiscoveryRESTMapper(grs)
+
+	if c.dynClient, err = dynamic.NewForConfig(cfg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
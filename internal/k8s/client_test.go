@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/derailed/popeye/pkg/config"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/informers"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/restmapper"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestOwnerChainWalksToRootController(t *testing.T) {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "dep-uid"},
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "web-abc123",
+			UID:       "rs-uid",
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(dep, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+			},
+		},
+	}
+
+	clientset := k8sfake.NewSimpleClientset(rs)
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	rsInformer := factory.Apps().V1().ReplicaSets()
+	if err := rsInformer.Informer().GetStore().Add(rs); err != nil {
+		t.Fatalf("seed ReplicaSet store: %v", err)
+	}
+
+	c := &Client{api: clientset, rsLister: rsInformer.Lister()}
+
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "web-abc123-xyz",
+			OwnerReferences: []metav1.OwnerReference{
+				// A non-controller ref listed first must not be mistaken for
+				// the owning controller.
+				{Kind: "Endpoints", Name: "not-a-controller"},
+				*metav1.NewControllerRef(rs, appsv1.SchemeGroupVersion.WithKind("ReplicaSet")),
+			},
+		},
+	}
+
+	chain := c.OwnerChain(context.Background(), pod)
+	if len(chain) != 2 {
+		t.Fatalf("expected a 2-link chain (ReplicaSet, Deployment), got %d: %+v", len(chain), chain)
+	}
+	if chain[0].Kind != "ReplicaSet" || chain[0].Name != "web-abc123" {
+		t.Errorf("expected first link to be the owning ReplicaSet, got %+v", chain[0])
+	}
+	if chain[1].Kind != "Deployment" || chain[1].Name != "web" {
+		t.Errorf("expected second link to be the owning Deployment, got %+v", chain[1])
+	}
+}
+
+func TestListCRFollowsContinueToken(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{gvr: "WidgetList"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds)
+
+	pages := [][]string{{"w1", "w2"}, {"w3"}}
+	var calls int
+	dyn.PrependReactor("list", "widgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if calls >= len(pages) {
+			t.Fatalf("unexpected List call past the last page (call %d)", calls+1)
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetAPIVersion("example.com/v1")
+		list.SetKind("WidgetList")
+		for _, name := range pages[calls] {
+			w := unstructured.Unstructured{}
+			w.SetAPIVersion("example.com/v1")
+			w.SetKind("Widget")
+			w.SetNamespace("default")
+			w.SetName(name)
+			list.Items = append(list.Items, w)
+		}
+
+		calls++
+		if calls < len(pages) {
+			list.SetContinue("page-" + strconv.Itoa(calls))
+		}
+
+		return true, list, nil
+	})
+
+	c := &Client{
+		Config:     &config.Config{PageSize: 2},
+		dynClient:  dyn,
+		restMapper: restmapper.NewDiscoveryRESTMapper(nil),
+	}
+
+	got, err := c.ListCR(context.Background(), gvr, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListCR: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 items across 2 pages, got %d: %+v", len(got), got)
+	}
+	if calls != len(pages) {
+		t.Fatalf("expected ListCR to follow the continue token across %d apiserver calls, got %d", len(pages), calls)
+	}
+	for _, name := range []string{"w1", "w2", "w3"} {
+		if _, ok := got[fqn("default", name)]; !ok {
+			t.Errorf("expected %s in the merged result, got %+v", name, got)
+		}
+	}
+}